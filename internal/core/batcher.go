@@ -1,10 +1,13 @@
 package core
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
+
+	"audit-log/internal/core/resilience"
 )
 
 type batchItem struct {
@@ -23,6 +26,20 @@ type MerkleBatchResult struct {
 	Proof     []MerkleProofStep
 	Err       string
 
+	// Drand-anchored timestamp (only set when the batcher has a Beacon enabled).
+	DrandRound      uint64
+	DrandRandomness string
+
+	// Retry/backoff spent on the ledger write when a resilience.Breaker is enabled (zero
+	// otherwise).
+	LedgerRetryCount     int
+	LedgerBackoffWaitSec float64
+
+	// BuildParallel reports whether any level of this batch's tree was large enough to take
+	// buildMerkleLevels' parallel hashing path, so parallel-vs-serial build times can be
+	// compared across flushes of varying batch size.
+	BuildParallel bool
+
 	// Timing (Unix ns)
 	EnqueueUnixNS     int64
 	FlushStartUnixNS  int64
@@ -37,12 +54,44 @@ type MerkleBatcher struct {
 	ledger    Ledger
 	batchSize int
 	maxWait   time.Duration
+	beacon    Beacon
+	proofSink ProofSink
+	breaker   *resilience.Breaker
 
 	in   chan *batchItem
 	stop chan struct{}
 	done chan struct{}
 }
 
+// EnableBeacon wires a drand (or other) Beacon into the batcher. Once enabled, every flush
+// pulls the current round before writing to the ledger and mixes the randomness into the
+// committed metadata, giving an externally-verifiable lower bound on commit time.
+func (b *MerkleBatcher) EnableBeacon(beacon Beacon) {
+	b.beacon = beacon
+}
+
+// ProofSink receives each leaf's sibling path at flush time, so a process separate from the
+// one that committed the batch (e.g. cmd/audit-verify, possibly run hours later) can
+// reconstruct an inclusion proof without re-deriving the whole tree, which would require
+// every other leaf in that batch still being available.
+type ProofSink interface {
+	SaveProof(leafHashHex string, proof []MerkleProofStep) error
+}
+
+// EnableProofSink wires a ProofSink into the batcher. Once enabled, every flush persists each
+// item's sibling path keyed by its leaf hash.
+func (b *MerkleBatcher) EnableProofSink(sink ProofSink) {
+	b.proofSink = sink
+}
+
+// EnableResilience wraps the batch's ledger commit in retry-with-backoff + circuit breaker via
+// breaker, mirroring AuditService.EnableResilience for the direct (non-batched) ledger write -
+// without this, a batch whose ledger write hits a transient failure fails the whole batch
+// instead of retrying it.
+func (b *MerkleBatcher) EnableResilience(breaker *resilience.Breaker) {
+	b.breaker = breaker
+}
+
 func NewMerkleBatcher(ledger Ledger, batchSize int, maxWait time.Duration) *MerkleBatcher {
 	if batchSize < 1 {
 		batchSize = 1
@@ -116,7 +165,7 @@ func (b *MerkleBatcher) loop() {
 		}
 
 		buildStart := time.Now()
-		levels, err := buildMerkleLevels(leaves)
+		levels, buildParallel, err := buildMerkleLevels(leaves)
 		buildEnd := time.Now()
 
 		if err != nil {
@@ -131,26 +180,53 @@ func (b *MerkleBatcher) loop() {
 
 		root := levels[len(levels)-1][0]
 		rootHex := hex.EncodeToString(root)
+
+		var drandRound uint64
+		var drandRandomnessHex string
+		if b.beacon != nil {
+			if round, randomness, err := b.beacon.Round(context.Background()); err == nil {
+				drandRound = round
+				drandRandomnessHex = hex.EncodeToString(randomness)
+			}
+			// A beacon fetch failure must not block the batch: the commit still happens,
+			// it just loses the externally-verifiable timestamp for this one flush.
+		}
+
 		meta := fmt.Sprintf(
 			"type=merkle_batch; root=%s; leaves=%d; leaf_algo=sha256(file_bytes); node_algo=sha256(l||r); created_at=%s",
 			rootHex, len(leaves), time.Now().UTC().Format(time.RFC3339Nano),
 		)
+		if drandRound > 0 {
+			meta += fmt.Sprintf("; drand_round=%d; drand_rand=%s", drandRound, drandRandomnessHex)
+		}
 
 		ledgerStart := time.Now()
-		txID, err := b.ledger.Write(rootHex, meta)
+		var txID string
+		var ledgerStats resilience.Stats
+		if b.breaker != nil {
+			ledgerStats, err = b.breaker.DoStats(context.Background(), "ledger", func() error {
+				var innerErr error
+				txID, innerErr = b.ledger.Write(rootHex, meta)
+				return innerErr
+			})
+		} else {
+			txID, err = b.ledger.Write(rootHex, meta)
+		}
 		ledgerEnd := time.Now()
 
 		if err != nil {
 			for _, it := range items {
 				it.resp <- MerkleBatchResult{
-					Err:               err.Error(),
-					EnqueueUnixNS:     it.enqueuedUnixNS,
-					FlushStartUnixNS:  flushStartNS,
-					BuildStartUnixNS:  buildStart.UnixNano(),
-					BuildEndUnixNS:    buildEnd.UnixNano(),
-					LedgerStartUnixNS: ledgerStart.UnixNano(),
-					LedgerEndUnixNS:   ledgerEnd.UnixNano(),
-					ResponseUnixNS:    time.Now().UnixNano(),
+					Err:                  err.Error(),
+					EnqueueUnixNS:        it.enqueuedUnixNS,
+					FlushStartUnixNS:     flushStartNS,
+					BuildStartUnixNS:     buildStart.UnixNano(),
+					BuildEndUnixNS:       buildEnd.UnixNano(),
+					LedgerStartUnixNS:    ledgerStart.UnixNano(),
+					LedgerEndUnixNS:      ledgerEnd.UnixNano(),
+					ResponseUnixNS:       time.Now().UnixNano(),
+					LedgerRetryCount:     ledgerStats.RetryCount,
+					LedgerBackoffWaitSec: ledgerStats.BackoffWaitSec,
 				}
 			}
 			return
@@ -158,6 +234,11 @@ func (b *MerkleBatcher) loop() {
 
 		for i, it := range items {
 			proof, _ := merkleProof(levels, i)
+			if b.proofSink != nil {
+				// Best-effort: a sink failure (e.g. disk full) must not fail the flush itself,
+				// it only means that one leaf's proof won't survive this process exiting.
+				_ = b.proofSink.SaveProof(hex.EncodeToString(it.leaf), proof)
+			}
 			it.resp <- MerkleBatchResult{
 				Root:      rootHex,
 				TxID:      txID,
@@ -165,6 +246,13 @@ func (b *MerkleBatcher) loop() {
 				BatchSize: len(items),
 				Proof:     proof,
 
+				DrandRound:      drandRound,
+				DrandRandomness: drandRandomnessHex,
+
+				LedgerRetryCount:     ledgerStats.RetryCount,
+				LedgerBackoffWaitSec: ledgerStats.BackoffWaitSec,
+				BuildParallel:        buildParallel,
+
 				EnqueueUnixNS:     it.enqueuedUnixNS,
 				FlushStartUnixNS:  flushStartNS,
 				BuildStartUnixNS:  buildStart.UnixNano(),
@@ -0,0 +1,283 @@
+package core
+
+import (
+	"context"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CustodyCheck is the per-document outcome of one custody sweep.
+type CustodyCheck struct {
+	DocID     string
+	HashHex   string
+	OK        bool
+	Error     string
+	CheckedAt time.Time
+}
+
+// CustodyReport is the persisted record of a full custody sweep: the summarized counters and
+// the merkle root committed to the ledger, which stands as the tamper-evident attestation that
+// at RunAt the operator still held valid copies of every document checked. Checks is kept
+// alongside for callers that want the per-document detail; it's marshalled to ChecksJSON for
+// storage rather than given its own table, matching how little schema the rest of this
+// service asks of the DB layer.
+type CustodyReport struct {
+	ID          string
+	RunAt       time.Time
+	DocsChecked int
+	BytesRead   int64
+	Mismatches  int
+	MerkleRoot  string
+	TxID        string
+	Checks      []CustodyCheck `gorm:"-"`
+	ChecksJSON  string         `gorm:"type:text"`
+}
+
+// CustodyStats is a point-in-time snapshot of a CustodyProver's counters, accumulated across
+// every sweep it has run.
+type CustodyStats struct {
+	BytesRead   int64
+	DocsChecked int64
+	Mismatches  int64
+}
+
+// CustodyProver periodically re-reads every document's blob from ObjectStorage, re-hashes it,
+// and asserts the result still matches the HashHex recorded at ingest - borrowing the periodic
+// re-proof idea from Filecoin's proof-of-spacetime, scoped to this operator's own store rather
+// than network-wide consensus. Each sweep's per-document outcomes are folded into a Merkle
+// tree and the root is committed to the Ledger through the existing MerkleBatcher, closing the
+// gap where the rest of this package only proves a document existed at ingest but never that
+// it still exists intact.
+type CustodyProver struct {
+	db      Database
+	store   ObjectStorage
+	batcher *MerkleBatcher
+	beacon  Beacon
+
+	bytesRead   int64
+	docsChecked int64
+	mismatches  int64
+
+	mu   sync.Mutex // guards stop/done lifecycle across Start/Stop
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewCustodyProver wires a prover that lists documents via db, re-reads blobs via store, and
+// commits sweep attestations through batcher. beacon may be nil, in which case the sweep order
+// is seeded from crypto/rand instead of drand randomness.
+func NewCustodyProver(db Database, store ObjectStorage, batcher *MerkleBatcher, beacon Beacon) *CustodyProver {
+	return &CustodyProver{db: db, store: store, batcher: batcher, beacon: beacon}
+}
+
+// Stats returns a snapshot of bytes-read / docs-checked / mismatches accumulated so far.
+func (p *CustodyProver) Stats() CustodyStats {
+	return CustodyStats{
+		BytesRead:   atomic.LoadInt64(&p.bytesRead),
+		DocsChecked: atomic.LoadInt64(&p.docsChecked),
+		Mismatches:  atomic.LoadInt64(&p.mismatches),
+	}
+}
+
+// RunOnce performs a single custody sweep: list every document, visit them in randomized
+// order, re-hash each blob against its recorded HashHex, and commit a Merkle-rooted
+// attestation of the results.
+func (p *CustodyProver) RunOnce(ctx context.Context) (*CustodyReport, error) {
+	docs, err := p.db.List()
+	if err != nil {
+		return nil, fmt.Errorf("custody: list documents: %w", err)
+	}
+
+	downloader, ok := p.store.(ObjectDownloader)
+	if !ok {
+		return nil, fmt.Errorf("custody: object store does not support downloads")
+	}
+
+	order, err := p.shuffleOrder(ctx, len(docs))
+	if err != nil {
+		return nil, fmt.Errorf("custody: derive sweep order: %w", err)
+	}
+
+	runAt := time.Now()
+	report := &CustodyReport{
+		ID:    fmt.Sprintf("custody-%d", runAt.UnixNano()),
+		RunAt: runAt,
+	}
+
+	leaves := make([][]byte, 0, len(docs))
+	for _, idx := range order {
+		doc := docs[idx]
+		check := p.checkOne(downloader, doc)
+		report.Checks = append(report.Checks, check)
+		report.DocsChecked++
+		if !check.OK {
+			report.Mismatches++
+		}
+		leaves = append(leaves, custodyLeaf(check))
+	}
+
+	if len(leaves) > 0 {
+		levels, _, err := buildMerkleLevels(leaves)
+		if err != nil {
+			return nil, fmt.Errorf("custody: build attestation tree: %w", err)
+		}
+		root := merkleRootFromLevels(levels)
+		report.MerkleRoot = hex.EncodeToString(root)
+
+		if p.batcher != nil {
+			res, err := p.batcher.Add(root)
+			if err != nil {
+				return nil, fmt.Errorf("custody: commit attestation: %w", err)
+			}
+			report.TxID = res.TxID
+		}
+	}
+
+	if raw, err := marshalChecks(report.Checks); err == nil {
+		report.ChecksJSON = raw
+	}
+
+	if err := p.db.SaveCustodyReport(report); err != nil {
+		return nil, fmt.Errorf("custody: save report: %w", err)
+	}
+
+	return report, nil
+}
+
+// checkOne re-reads a single document's blob and compares its hash against doc.HashHex.
+func (p *CustodyProver) checkOne(downloader ObjectDownloader, doc *Document) CustodyCheck {
+	check := CustodyCheck{DocID: doc.ID, CheckedAt: time.Now()}
+
+	rc, err := downloader.Download(doc.StoragePath)
+	if err != nil {
+		atomic.AddInt64(&p.docsChecked, 1)
+		atomic.AddInt64(&p.mismatches, 1)
+		check.Error = err.Error()
+		return check
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, rc)
+	atomic.AddInt64(&p.bytesRead, n)
+	atomic.AddInt64(&p.docsChecked, 1)
+	if err != nil {
+		atomic.AddInt64(&p.mismatches, 1)
+		check.Error = err.Error()
+		return check
+	}
+
+	check.HashHex = hex.EncodeToString(h.Sum(nil))
+	check.OK = check.HashHex == doc.HashHex
+	if !check.OK {
+		atomic.AddInt64(&p.mismatches, 1)
+	}
+	return check
+}
+
+// shuffleOrder derives a permutation of [0, n) seeded from the drand beacon when one is
+// configured (so the sweep order is itself tied to externally-verifiable randomness), falling
+// back to crypto/rand otherwise.
+func (p *CustodyProver) shuffleOrder(ctx context.Context, n int) ([]int, error) {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if n <= 1 {
+		return order, nil
+	}
+
+	seed, err := p.seed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := mathrand.New(mathrand.NewSource(seed))
+	rng.Shuffle(n, func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return order, nil
+}
+
+func (p *CustodyProver) seed(ctx context.Context) (int64, error) {
+	if p.beacon != nil {
+		if _, randomness, err := p.beacon.Round(ctx); err == nil && len(randomness) >= 8 {
+			return int64(binary.BigEndian.Uint64(randomness[:8])), nil
+		}
+	}
+
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
+func marshalChecks(checks []CustodyCheck) (string, error) {
+	b, err := json.Marshal(checks)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// custodyLeaf folds one (doc_id, hash, timestamp, ok) tuple into a single sha256 leaf for the
+// sweep's attestation tree.
+func custodyLeaf(check CustodyCheck) []byte {
+	okFlag := "0"
+	if check.OK {
+		okFlag = "1"
+	}
+	buf := fmt.Sprintf("%s|%s|%d|%s", check.DocID, check.HashHex, check.CheckedAt.UnixNano(), okFlag)
+	sum := sha256.Sum256([]byte(buf))
+	return sum[:]
+}
+
+// Start runs RunOnce on a fixed cadence until Stop is called. A sweep that errors is logged
+// nowhere (the caller's choice of logging infra is out of scope here) and simply waits for the
+// next tick rather than killing the prover's goroutine.
+func (p *CustodyProver) Start(interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil {
+		return // already running
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+
+	stop := p.stop
+	done := p.done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_, _ = p.RunOnce(context.Background())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts a running prover started with Start and waits for its goroutine to exit.
+func (p *CustodyProver) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.stop = nil
+	p.done = nil
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeCustodyDB is a minimal in-memory Database double for custody sweeps - it only needs to
+// list documents and record the saved report, so it skips MemoryDB's full Get/Save semantics.
+type fakeCustodyDB struct {
+	mu     sync.Mutex
+	docs   []*Document
+	report *CustodyReport
+}
+
+func (f *fakeCustodyDB) Save(doc *Document) error { return nil }
+func (f *fakeCustodyDB) Get(id string) (*Document, error) {
+	for _, d := range f.docs {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+func (f *fakeCustodyDB) List() ([]*Document, error) { return f.docs, nil }
+func (f *fakeCustodyDB) SaveCustodyReport(report *CustodyReport) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.report = report
+	return nil
+}
+
+// fakeObjectStore is a minimal ObjectStorage+ObjectDownloader double backed by an in-memory
+// blob map, keyed by StoragePath.
+type fakeObjectStore struct {
+	blobs map[string][]byte
+}
+
+func (f *fakeObjectStore) Upload(sha256Hex string, data io.Reader, size int64) (string, bool, error) {
+	return "", false, errors.New("not implemented")
+}
+
+func (f *fakeObjectStore) Download(path string) (io.ReadCloser, error) {
+	b, ok := f.blobs[path]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func hashOf(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCustodyProverCheckOneMatch(t *testing.T) {
+	blob := []byte("classified report body")
+	store := &fakeObjectStore{blobs: map[string][]byte{"path/1": blob}}
+	p := NewCustodyProver(&fakeCustodyDB{}, store, nil, nil)
+
+	doc := &Document{ID: "doc-1", StoragePath: "path/1", HashHex: hashOf(blob)}
+	check := p.checkOne(store, doc)
+
+	if !check.OK {
+		t.Fatalf("check = %+v, want OK=true", check)
+	}
+	if check.Error != "" {
+		t.Fatalf("check.Error = %q, want empty", check.Error)
+	}
+	if stats := p.Stats(); stats.Mismatches != 0 || stats.DocsChecked != 1 {
+		t.Fatalf("Stats = %+v, want DocsChecked=1 Mismatches=0", stats)
+	}
+}
+
+func TestCustodyProverCheckOneMismatch(t *testing.T) {
+	blob := []byte("classified report body")
+	store := &fakeObjectStore{blobs: map[string][]byte{"path/1": blob}}
+	p := NewCustodyProver(&fakeCustodyDB{}, store, nil, nil)
+
+	doc := &Document{ID: "doc-1", StoragePath: "path/1", HashHex: "not-the-real-hash"}
+	check := p.checkOne(store, doc)
+
+	if check.OK {
+		t.Fatalf("check = %+v, want OK=false", check)
+	}
+	if stats := p.Stats(); stats.Mismatches != 1 || stats.DocsChecked != 1 {
+		t.Fatalf("Stats = %+v, want DocsChecked=1 Mismatches=1", stats)
+	}
+}
+
+func TestCustodyProverCheckOneDownloadError(t *testing.T) {
+	store := &fakeObjectStore{blobs: map[string][]byte{}}
+	p := NewCustodyProver(&fakeCustodyDB{}, store, nil, nil)
+
+	doc := &Document{ID: "doc-1", StoragePath: "missing/path", HashHex: "deadbeef"}
+	check := p.checkOne(store, doc)
+
+	if check.OK {
+		t.Fatalf("check = %+v, want OK=false", check)
+	}
+	if check.Error == "" {
+		t.Fatal("check.Error is empty, want the download error recorded")
+	}
+	if stats := p.Stats(); stats.Mismatches != 1 || stats.DocsChecked != 1 {
+		t.Fatalf("Stats = %+v, want DocsChecked=1 Mismatches=1", stats)
+	}
+}
+
+func TestCustodyProverRunOnceBuildsReportAndDetectsMismatch(t *testing.T) {
+	goodBlob := []byte("doc one body")
+	badBlob := []byte("doc two body")
+	store := &fakeObjectStore{blobs: map[string][]byte{
+		"path/1": goodBlob,
+		"path/2": badBlob,
+	}}
+	db := &fakeCustodyDB{docs: []*Document{
+		{ID: "doc-1", StoragePath: "path/1", HashHex: hashOf(goodBlob)},
+		{ID: "doc-2", StoragePath: "path/2", HashHex: "wrong-hash"},
+	}}
+	// No MerkleBatcher and no Beacon: RunOnce should fall back to crypto/rand for sweep order
+	// and skip committing the attestation root to a ledger.
+	p := NewCustodyProver(db, store, nil, nil)
+
+	report, err := p.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if report.DocsChecked != 2 {
+		t.Fatalf("DocsChecked = %d, want 2", report.DocsChecked)
+	}
+	if report.Mismatches != 1 {
+		t.Fatalf("Mismatches = %d, want 1", report.Mismatches)
+	}
+	if report.MerkleRoot == "" {
+		t.Fatal("MerkleRoot is empty, want a committed attestation root")
+	}
+	if report.TxID != "" {
+		t.Fatalf("TxID = %q, want empty with no batcher configured", report.TxID)
+	}
+	if report.ChecksJSON == "" {
+		t.Fatal("ChecksJSON is empty, want the marshalled per-document checks")
+	}
+	if db.report != report {
+		t.Fatal("SaveCustodyReport was not called with the report RunOnce returned")
+	}
+
+	stats := p.Stats()
+	if stats.DocsChecked != 2 || stats.Mismatches != 1 {
+		t.Fatalf("Stats = %+v, want DocsChecked=2 Mismatches=1", stats)
+	}
+}
+
+func TestCustodyProverRunOnceRejectsNonDownloadableStore(t *testing.T) {
+	type uploadOnlyStore struct {
+		ObjectStorage
+	}
+	db := &fakeCustodyDB{docs: []*Document{{ID: "doc-1", StoragePath: "path/1", HashHex: "x"}}}
+	p := NewCustodyProver(db, uploadOnlyStore{}, nil, nil)
+
+	if _, err := p.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected an error when the object store doesn't support downloads")
+	}
+}
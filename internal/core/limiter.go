@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter caps the throughput of the external-facing stages ProcessDocument drives: bytes/sec
+// written to object storage, ops/sec of direct (non-batched) ledger submissions, and the number
+// of documents allowed to wait on a Merkle batch commit at once. Each dimension is independent
+// and optional; a disabled dimension never blocks.
+type Limiter struct {
+	storage  *rate.Limiter // bytes/sec
+	ledger   *rate.Limiter // ops/sec
+	inflight chan struct{} // slots for documents waiting on a merkle batch commit
+}
+
+// storageBurstBytes caps the storage token bucket's burst independently of its bytes/sec rate.
+// It must be large enough to cover any single document this process uploads in one shot -
+// otherwise rate.Limiter.WaitN returns an error instead of blocking for any document bigger than
+// the configured MB/s, throttling would reject large uploads outright rather than slowing them.
+const storageBurstBytes = 64 * 1024 * 1024 // 64MiB
+
+// NewLimiter builds a Limiter from operator-facing units: storageMBps caps MinIO upload
+// throughput in megabytes/sec, ledgerTPS caps direct (non-batched) Fabric submissions/sec -
+// Merkle batch commits are not rate limited - and maxInflightBatches caps how many documents
+// may wait on a Merkle batch commit at once (one slot per document, not per batch). A value
+// <= 0 leaves that dimension uncapped.
+func NewLimiter(storageMBps float64, ledgerTPS float64, maxInflightBatches int) *Limiter {
+	l := &Limiter{}
+	if storageMBps > 0 {
+		bps := storageMBps * 1024 * 1024
+		l.storage = rate.NewLimiter(rate.Limit(bps), storageBurstBytes)
+	}
+	if ledgerTPS > 0 {
+		l.ledger = rate.NewLimiter(rate.Limit(ledgerTPS), int(ledgerTPS)+1)
+	}
+	if maxInflightBatches > 0 {
+		l.inflight = make(chan struct{}, maxInflightBatches)
+	}
+	return l
+}
+
+// WaitStorage blocks until n bytes are allowed onto the wire to object storage, returning how
+// long the call waited.
+func (l *Limiter) WaitStorage(ctx context.Context, n int) (time.Duration, error) {
+	if l == nil || l.storage == nil {
+		return 0, nil
+	}
+	start := time.Now()
+	err := l.storage.WaitN(ctx, n)
+	return time.Since(start), err
+}
+
+// WaitLedger blocks until one ledger submission is allowed, returning how long the call waited.
+func (l *Limiter) WaitLedger(ctx context.Context) (time.Duration, error) {
+	if l == nil || l.ledger == nil {
+		return 0, nil
+	}
+	start := time.Now()
+	err := l.ledger.Wait(ctx)
+	return time.Since(start), err
+}
+
+// AcquireBatchSlot blocks until a slot for one document's Merkle batch commit is free,
+// returning a release func to call once that document's batch has been committed, plus how
+// long it waited.
+func (l *Limiter) AcquireBatchSlot(ctx context.Context) (release func(), waited time.Duration, err error) {
+	if l == nil || l.inflight == nil {
+		return func() {}, 0, nil
+	}
+	start := time.Now()
+	select {
+	case l.inflight <- struct{}{}:
+		return func() { <-l.inflight }, time.Since(start), nil
+	case <-ctx.Done():
+		return func() {}, time.Since(start), ctx.Err()
+	}
+}
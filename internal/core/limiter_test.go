@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterNilIsNoOp(t *testing.T) {
+	var l *Limiter
+	if _, err := l.WaitStorage(context.Background(), 1<<30); err != nil {
+		t.Fatalf("WaitStorage on nil Limiter: %v", err)
+	}
+	if _, err := l.WaitLedger(context.Background()); err != nil {
+		t.Fatalf("WaitLedger on nil Limiter: %v", err)
+	}
+	release, _, err := l.AcquireBatchSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireBatchSlot on nil Limiter: %v", err)
+	}
+	release()
+}
+
+func TestLimiterDisabledDimensionsDontBlock(t *testing.T) {
+	l := NewLimiter(0, 0, 0)
+	if _, err := l.WaitStorage(context.Background(), 1<<30); err != nil {
+		t.Fatalf("WaitStorage with storageMBps<=0: %v", err)
+	}
+	if _, err := l.WaitLedger(context.Background()); err != nil {
+		t.Fatalf("WaitLedger with ledgerTPS<=0: %v", err)
+	}
+	release, _, err := l.AcquireBatchSlot(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireBatchSlot with maxInflightBatches<=0: %v", err)
+	}
+	release()
+}
+
+// TestLimiterStorageBurstCoversRateExceedingUploads pins the fix that decoupled the storage
+// burst from its bytes/sec rate: before that fix, burst was derived from bps, so a single
+// upload bigger than the configured MB/s rate would make WaitN fail outright instead of
+// blocking. A 1MB/s limiter should still let a 10MB write through immediately because it fits
+// under storageBurstBytes.
+func TestLimiterStorageBurstCoversRateExceedingUploads(t *testing.T) {
+	l := NewLimiter(1 /* MB/s */, 0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	const tenMB = 10 * 1024 * 1024
+	waited, err := l.WaitStorage(ctx, tenMB)
+	if err != nil {
+		t.Fatalf("WaitStorage(%d bytes) on a 1MB/s limiter: %v", tenMB, err)
+	}
+	if waited > time.Second {
+		t.Fatalf("WaitStorage waited %v for a write within burst, want ~immediate", waited)
+	}
+}
+
+func TestLimiterStorageThrottlesAcrossRequests(t *testing.T) {
+	l := NewLimiter(1 /* MB/s */, 0, 0)
+	ctx := context.Background()
+
+	const oneMB = 1024 * 1024
+	// The bucket starts full at storageBurstBytes, so drain the whole burst first; only then
+	// does the next write have to wait for tokens to refill at 1MB/s.
+	if _, err := l.WaitStorage(ctx, storageBurstBytes); err != nil {
+		t.Fatalf("first WaitStorage: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := l.WaitStorage(ctx, oneMB*2); err != nil {
+		t.Fatalf("second WaitStorage: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Fatalf("second WaitStorage returned after %v, want it to wait for tokens to refill", elapsed)
+	}
+}
+
+func TestLimiterAcquireBatchSlotEnforcesCapacity(t *testing.T) {
+	l := NewLimiter(0, 0, 1)
+	ctx := context.Background()
+
+	release, _, err := l.AcquireBatchSlot(ctx)
+	if err != nil {
+		t.Fatalf("first AcquireBatchSlot: %v", err)
+	}
+
+	blockedCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := l.AcquireBatchSlot(blockedCtx); err == nil {
+		t.Fatal("second AcquireBatchSlot should have blocked until the deadline while the slot is held")
+	}
+
+	release()
+
+	release2, _, err := l.AcquireBatchSlot(ctx)
+	if err != nil {
+		t.Fatalf("AcquireBatchSlot after release: %v", err)
+	}
+	release2()
+}
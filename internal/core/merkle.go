@@ -3,10 +3,16 @@ package core
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
+	"runtime"
+	"sync"
 )
 
+// parallelPairThreshold is the minimum number of sibling pairs a level must have before
+// buildMerkleLevels fans the hashing out across a worker pool instead of hashing serially.
+// Below this the goroutine/scheduling overhead outweighs the savings.
+const parallelPairThreshold = 100
+
 // MerkleProofStep represents one step in an inclusion proof.
 // Side indicates where the sibling hash sits relative to the running hash:
 // - "L" means sibling is on the left: H = sha256(sibling || current)
@@ -17,16 +23,18 @@ type MerkleProofStep struct {
 }
 
 // buildMerkleLevels builds all Merkle levels (level 0 = leaves). If the level has an odd
-// number of nodes, the last node is duplicated (Bitcoin-style) to form a pair.
-func buildMerkleLevels(leaves [][]byte) ([][][]byte, error) {
+// number of nodes, the last node is duplicated (Bitcoin-style) to form a pair. The returned bool
+// reports whether any level was large enough to take the parallel hashing path, so callers can
+// record which path a given build actually took (see MerkleBatchResult.BuildParallel).
+func buildMerkleLevels(leaves [][]byte) ([][][]byte, bool, error) {
 	if len(leaves) == 0 {
-		return nil, errors.New("no leaves")
+		return nil, false, errors.New("no leaves")
 	}
 
 	lvl0 := make([][]byte, len(leaves))
 	for i := range leaves {
 		if len(leaves[i]) == 0 {
-			return nil, errors.New("empty leaf")
+			return nil, false, errors.New("empty leaf")
 		}
 		// Copy to avoid caller mutation.
 		b := make([]byte, len(leaves[i]))
@@ -34,30 +42,99 @@ func buildMerkleLevels(leaves [][]byte) ([][][]byte, error) {
 		lvl0[i] = b
 	}
 
+	var parallel bool
 	levels := [][][]byte{lvl0}
 	for {
 		curr := levels[len(levels)-1]
 		if len(curr) == 1 {
 			break
 		}
-		next := make([][]byte, 0, (len(curr)+1)/2)
-		for i := 0; i < len(curr); i += 2 {
-			left := curr[i]
-			right := left
-			if i+1 < len(curr) {
-				right = curr[i+1]
-			}
-			buf := make([]byte, 0, len(left)+len(right))
-			buf = append(buf, left...)
-			buf = append(buf, right...)
-			sum := sha256.Sum256(buf)
-			parent := make([]byte, len(sum))
-			copy(parent, sum[:])
-			next = append(next, parent)
+		numPairs := (len(curr) + 1) / 2
+		var next [][]byte
+		if numPairs > parallelPairThreshold {
+			next = hashLevelParallel(curr)
+			parallel = true
+		} else {
+			next = hashLevelSerial(curr)
 		}
 		levels = append(levels, next)
 	}
-	return levels, nil
+	return levels, parallel, nil
+}
+
+// hashPair computes the parent of a (left, right) sibling pair. When the level has an odd
+// number of nodes, right may equal left (duplicated per Bitcoin-style convention).
+func hashPair(left, right []byte) []byte {
+	buf := make([]byte, 0, len(left)+len(right))
+	buf = append(buf, left...)
+	buf = append(buf, right...)
+	sum := sha256.Sum256(buf)
+	parent := make([]byte, len(sum))
+	copy(parent, sum[:])
+	return parent
+}
+
+// hashLevelSerial hashes sibling pairs one at a time. Used for small levels where spinning
+// up a worker pool costs more than it saves.
+func hashLevelSerial(curr [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(curr)+1)/2)
+	for i := 0; i < len(curr); i += 2 {
+		left := curr[i]
+		right := left
+		if i+1 < len(curr) {
+			right = curr[i+1]
+		}
+		next = append(next, hashPair(left, right))
+	}
+	return next
+}
+
+// hashLevelParallel hashes sibling pairs fanned out across a worker pool sized to GOMAXPROCS.
+// Each worker owns a contiguous range of pair indices and writes into its own slots of `next`,
+// so no locking is needed since the writes never overlap. The byte-level output is identical
+// to hashLevelSerial: same pairing, same duplicate-last-node rule, same hash order.
+func hashLevelParallel(curr [][]byte) [][]byte {
+	numPairs := (len(curr) + 1) / 2
+	next := make([][]byte, numPairs)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > numPairs {
+		workers = numPairs
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	chunk := (numPairs + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		if start >= numPairs {
+			break
+		}
+		end := start + chunk
+		if end > numPairs {
+			end = numPairs
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for p := start; p < end; p++ {
+				i := p * 2
+				left := curr[i]
+				right := left
+				if i+1 < len(curr) {
+					right = curr[i+1]
+				}
+				next[p] = hashPair(left, right)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return next
 }
 
 func merkleRootFromLevels(levels [][][]byte) []byte {
@@ -133,33 +210,16 @@ func computeRootFromProof(leaf []byte, proof []MerkleProofStep) ([]byte, error)
 
 // VerifyMerkleProof verifies that (leafHashHex, proofJSON) produces expectedRootHex.
 // proofJSON is a JSON array of MerkleProofStep.
+//
+// This is a thin wrapper over the package-level default ProofVerifier: a single call still
+// verifies synchronously from the caller's point of view, but the work happens on a pooled
+// worker goroutine with reused decode buffers, so a caller checking many documents in a loop
+// gets the worker-pool benefits for free.
 func VerifyMerkleProof(leafHashHex string, proofJSON string, expectedRootHex string) (bool, error) {
-	leaf, err := hex.DecodeString(leafHashHex)
-	if err != nil {
-		return false, errors.New("invalid leaf hash encoding")
-	}
-	expectedRoot, err := hex.DecodeString(expectedRootHex)
-	if err != nil {
-		return false, errors.New("invalid root hash encoding")
-	}
-
-	var proof []MerkleProofStep
-	if err := json.Unmarshal([]byte(proofJSON), &proof); err != nil {
-		return false, errors.New("invalid proof json")
-	}
-
-	computed, err := computeRootFromProof(leaf, proof)
-	if err != nil {
-		return false, err
-	}
-
-	if len(computed) != len(expectedRoot) {
-		return false, nil
-	}
-	for i := range computed {
-		if computed[i] != expectedRoot[i] {
-			return false, nil
-		}
-	}
-	return true, nil
+	res := <-defaultVerifier.Submit(VerifyRequest{
+		LeafHex:   leafHashHex,
+		ProofJSON: proofJSON,
+		RootHex:   expectedRootHex,
+	})
+	return res.OK, res.Err
 }
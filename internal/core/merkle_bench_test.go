@@ -0,0 +1,42 @@
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+)
+
+// benchLeaves builds n distinct 32-byte leaves for benchmarking hashLevelSerial/hashLevelParallel
+// without depending on buildMerkleLevels' own threshold selection.
+func benchLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("bench-leaf-%d", i)))
+		leaves[i] = sum[:]
+	}
+	return leaves
+}
+
+func BenchmarkHashLevelSerial(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		leaves := benchLeaves(n)
+		b.Run(fmt.Sprintf("pairs=%d", (n+1)/2), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				hashLevelSerial(leaves)
+			}
+		})
+	}
+}
+
+func BenchmarkHashLevelParallel(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		leaves := benchLeaves(n)
+		b.Run(fmt.Sprintf("pairs=%d", (n+1)/2), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				hashLevelParallel(leaves)
+			}
+		})
+	}
+}
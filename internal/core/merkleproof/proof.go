@@ -0,0 +1,191 @@
+// Package merkleproof lets a process independent of the one that committed a batch (typically
+// cmd/audit-verify, run well after the fact) prove that a document belongs to a Merkle root
+// recorded on the ledger. It pairs a persisted ProofStore of per-leaf sibling paths - written
+// by core.MerkleBatcher at flush time via the core.ProofSink hook - with a Verify step that
+// folds the path up to a root and compares it against what the ledger actually has on record.
+package merkleproof
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"audit-log/internal/core"
+)
+
+// ProofStore persists the sibling path produced for each leaf when its batch is flushed.
+// Implementations only need SaveProof to satisfy core.ProofSink; LoadProof is what
+// cmd/audit-verify uses later to pull a path back out.
+type ProofStore interface {
+	SaveProof(leafHashHex string, proof []core.MerkleProofStep) error
+	LoadProof(leafHashHex string) ([]core.MerkleProofStep, error)
+}
+
+// FileProofStore is a ProofStore backed by a single JSON file, so proofs survive the bench
+// process that committed them exiting before a verifier runs.
+type FileProofStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileProofStore returns a ProofStore that reads/writes proofs to a JSON file at path.
+// The file is created on first SaveProof; it doesn't need to exist beforehand.
+func NewFileProofStore(path string) *FileProofStore {
+	return &FileProofStore{path: path}
+}
+
+func (s *FileProofStore) load() (map[string][]core.MerkleProofStep, error) {
+	raw, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string][]core.MerkleProofStep{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]core.MerkleProofStep{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// SaveProof satisfies core.ProofSink: core.MerkleBatcher calls this once per leaf at flush time.
+func (s *FileProofStore) SaveProof(leafHashHex string, proof []core.MerkleProofStep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	all[leafHashHex] = proof
+
+	raw, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, raw, 0644)
+}
+
+// LoadProof returns the sibling path previously saved for leafHashHex.
+func (s *FileProofStore) LoadProof(leafHashHex string) ([]core.MerkleProofStep, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	proof, ok := all[leafHashHex]
+	if !ok {
+		return nil, fmt.Errorf("no proof stored for leaf %s", leafHashHex)
+	}
+	return proof, nil
+}
+
+// Verify recomputes the root implied by doc's content hash and proof, folding
+// H(left||right) up the tree (odd-sized levels duplicate the last node, matching
+// MerkleBatcher's build convention), and compares it against root.
+func Verify(doc core.Document, proof []core.MerkleProofStep, root []byte) error {
+	raw, err := json.Marshal(proof)
+	if err != nil {
+		return fmt.Errorf("encode proof: %w", err)
+	}
+
+	ok, err := core.VerifyMerkleProof(doc.HashHex, string(raw), hex.EncodeToString(root))
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("computed root does not match the root on record for %s", doc.ID)
+	}
+	return nil
+}
+
+// VerifyDocument runs the full verification pipeline for one document: re-derive its leaf hash
+// (catching silent storage corruption), load the stored sibling path, fetch the committed root
+// from the ledger, and check inclusion. It's shared between cmd/main.go's -verify-sample
+// sampling and cmd/audit-verify so both run exactly the same checks.
+//
+// For a chunked document (doc.ChunkCount > 0) doc.StoragePath is a synthetic manifest string,
+// not a downloadable object, so chunks is used instead: every chunk is re-downloaded and its
+// inclusion proof against doc.ChunkRoot is checked, and since chunked storage sets doc.HashHex
+// to doc.ChunkRoot, proving that folds straight into the same ledger-inclusion check a
+// whole-file document gets. Either downloader or chunks may be nil if the caller has no use for
+// that path; VerifyDocument errors clearly if the one the document actually needs is missing.
+func VerifyDocument(doc core.Document, downloader core.ObjectDownloader, chunks core.ChunkReader, proofs ProofStore, ledger core.Ledger) error {
+	if doc.ChunkCount > 0 {
+		if chunks == nil {
+			return fmt.Errorf("document %s is chunked but no ChunkReader was provided", doc.ID)
+		}
+		if err := verifyChunks(doc, chunks); err != nil {
+			return err
+		}
+	} else {
+		if downloader == nil {
+			return fmt.Errorf("document %s requires an ObjectDownloader", doc.ID)
+		}
+		rc, err := downloader.Download(doc.StoragePath)
+		if err != nil {
+			return fmt.Errorf("download blob: %w", err)
+		}
+		defer rc.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(h, rc); err != nil {
+			return fmt.Errorf("read blob: %w", err)
+		}
+		leafHex := hex.EncodeToString(h.Sum(nil))
+		if leafHex != doc.HashHex {
+			return fmt.Errorf("blob corrupted: recomputed hash %s != recorded hash %s", leafHex, doc.HashHex)
+		}
+	}
+
+	proof, err := proofs.LoadProof(doc.HashHex)
+	if err != nil {
+		return fmt.Errorf("load proof: %w", err)
+	}
+
+	root, err := ledger.GetRoot(doc.TxID)
+	if err != nil {
+		return fmt.Errorf("fetch root from ledger: %w", err)
+	}
+
+	return Verify(doc, proof, root)
+}
+
+// verifyChunks re-downloads every chunk of a chunked document via chunks.ReadChunk and checks
+// each one's inclusion proof against doc.ChunkRoot, so a corrupted or missing chunk is caught
+// without ever pulling the rest of the file.
+func verifyChunks(doc core.Document, chunks core.ChunkReader) error {
+	if doc.ChunkCount == 0 {
+		return fmt.Errorf("document %s has no chunks to verify", doc.ID)
+	}
+	for i := 0; i < doc.ChunkCount; i++ {
+		data, proof, err := chunks.ReadChunk(doc.ID, i)
+		if err != nil {
+			return fmt.Errorf("read chunk %d: %w", i, err)
+		}
+		sum := sha256.Sum256(data)
+		leafHex := hex.EncodeToString(sum[:])
+
+		raw, err := json.Marshal(proof)
+		if err != nil {
+			return fmt.Errorf("encode chunk %d proof: %w", i, err)
+		}
+		ok, err := core.VerifyMerkleProof(leafHex, string(raw), doc.ChunkRoot)
+		if err != nil {
+			return fmt.Errorf("verify chunk %d: %w", i, err)
+		}
+		if !ok {
+			return fmt.Errorf("chunk %d does not fold up to the document's chunk root", i)
+		}
+	}
+	return nil
+}
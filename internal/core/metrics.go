@@ -31,6 +31,18 @@ type DocumentMetrics struct {
 	MerkleResponseUnixNS    int64
 	MerkleLeafIndex         int
 	MerkleBatchSize         int
+	DrandRound              uint64
+	DrandRandomness         string
+	MerkleBuildParallel     bool
+
+	// Chunked storage per-chunk hash/upload timings, summarized since chunk count varies
+	// per document.
+	ChunkHashMinSec     float64
+	ChunkHashMaxSec     float64
+	ChunkHashMeanSec    float64
+	ChunkStorageMinSec  float64
+	ChunkStorageMaxSec  float64
+	ChunkStorageMeanSec float64
 
 	// Ledger write (per-document, only when merkle disabled and blockchain enabled)
 	LedgerStartUnixNS int64
@@ -50,4 +62,16 @@ type DocumentMetrics struct {
 	MerkleWaitSec   float64
 	MerkleBuildSec  float64
 	MerkleLedgerSec float64
+
+	// Time spent blocked on a Limiter, if one is enabled (zero otherwise).
+	StorageThrottleSec float64
+	LedgerThrottleSec  float64
+
+	// Retry/backoff spent per stage when a resilience.Breaker is enabled (zero otherwise).
+	StorageRetryCount     int
+	StorageBackoffWaitSec float64
+	DBRetryCount          int
+	DBBackoffWaitSec      float64
+	LedgerRetryCount      int
+	LedgerBackoffWaitSec  float64
 }
@@ -0,0 +1,160 @@
+// Package resilience wraps calls to external endpoints (object storage, the DB, the ledger)
+// with exponential backoff + jitter retries and a per-endpoint circuit breaker, so a transient
+// blip - a MinIO timeout, a Fabric peer restart - degrades latency instead of failing the whole
+// job outright, and a sustained outage stops hammering a dead endpoint instead of piling up
+// retries against it.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// State is a single endpoint's circuit breaker lifecycle stage.
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+// ErrBreakerOpen is returned by Do without calling fn when an endpoint's breaker is open and
+// its cooldown hasn't elapsed yet.
+var ErrBreakerOpen = errors.New("resilience: circuit breaker open")
+
+// Policy configures retry/backoff and circuit-breaker behavior, shared across every endpoint a
+// Breaker tracks.
+type Policy struct {
+	MaxRetries       int           // attempts after the first, so total tries = MaxRetries+1
+	BaseDelay        time.Duration // backoff base; attempt n waits up to BaseDelay*2^(n-1), full jitter
+	MaxDelay         time.Duration // cap on any single backoff wait
+	BreakerThreshold int           // consecutive failures before the breaker opens
+	CooldownPeriod   time.Duration // how long an open breaker stays open before a half-open probe
+}
+
+// DefaultPolicy is used by anything that constructs a Breaker without an explicit Policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRetries:       3,
+		BaseDelay:        50 * time.Millisecond,
+		MaxDelay:         2 * time.Second,
+		BreakerThreshold: 5,
+		CooldownPeriod:   5 * time.Second,
+	}
+}
+
+type endpointState struct {
+	mu              sync.Mutex
+	state           State
+	consecutiveFail int
+	openedAt        time.Time
+}
+
+// Breaker runs operations through Do against a named endpoint ("storage", "ledger", "db", ...),
+// retrying with exponential backoff on failure and tripping that endpoint's circuit after
+// Policy.BreakerThreshold consecutive failures.
+type Breaker struct {
+	policy Policy
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointState
+}
+
+// NewBreaker builds a Breaker governed by policy.
+func NewBreaker(policy Policy) *Breaker {
+	return &Breaker{policy: policy, endpoints: make(map[string]*endpointState)}
+}
+
+func (b *Breaker) endpoint(op string) *endpointState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e, ok := b.endpoints[op]
+	if !ok {
+		e = &endpointState{}
+		b.endpoints[op] = e
+	}
+	return e
+}
+
+// Stats reports how much a single Do call retried and waited, so callers can fold it into
+// per-request metrics without having to reach into the Breaker's internals.
+type Stats struct {
+	RetryCount     int
+	BackoffWaitSec float64
+}
+
+// Do runs fn against the named endpoint, retrying on error with exponential backoff + full
+// jitter up to Policy.MaxRetries times. If that endpoint's breaker is open and the cooldown
+// hasn't elapsed, fn is never called and ErrBreakerOpen is returned.
+func (b *Breaker) Do(ctx context.Context, op string, fn func() error) error {
+	_, err := b.DoStats(ctx, op, fn)
+	return err
+}
+
+// DoStats behaves like Do but also returns how many retries and how much backoff time the call
+// spent, so AuditService.ProcessDocument can surface retry_count / backoff_wait_sec per stage.
+func (b *Breaker) DoStats(ctx context.Context, op string, fn func() error) (Stats, error) {
+	e := b.endpoint(op)
+
+	e.mu.Lock()
+	if e.state == Open {
+		if time.Since(e.openedAt) < b.policy.CooldownPeriod {
+			e.mu.Unlock()
+			return Stats{}, fmt.Errorf("%s: %w", op, ErrBreakerOpen)
+		}
+		e.state = HalfOpen
+	}
+	e.mu.Unlock()
+
+	var stats Stats
+	var lastErr error
+	for attempt := 0; attempt <= b.policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := backoffDelay(b.policy, attempt)
+			stats.BackoffWaitSec += delay.Seconds()
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return stats, ctx.Err()
+			}
+			stats.RetryCount++
+		}
+
+		lastErr = fn()
+		if lastErr == nil {
+			e.mu.Lock()
+			e.consecutiveFail = 0
+			e.state = Closed
+			e.mu.Unlock()
+			return stats, nil
+		}
+
+		e.mu.Lock()
+		e.consecutiveFail++
+		if e.consecutiveFail >= b.policy.BreakerThreshold {
+			e.state = Open
+			e.openedAt = time.Now()
+		}
+		e.mu.Unlock()
+	}
+
+	return stats, fmt.Errorf("%s: %w (after %d attempts)", op, lastErr, stats.RetryCount+1)
+}
+
+// backoffDelay computes the exponential delay for attempt (1-indexed), with full jitter and a
+// cap at Policy.MaxDelay.
+func backoffDelay(p Policy, attempt int) time.Duration {
+	max := p.BaseDelay << uint(attempt-1)
+	if p.MaxDelay > 0 && max > p.MaxDelay {
+		max = p.MaxDelay
+	}
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
@@ -0,0 +1,175 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fastPolicy keeps retry/backoff/cooldown timing short enough to exercise in a unit test
+// without the suite taking seconds to run.
+func fastPolicy() Policy {
+	return Policy{
+		MaxRetries:       2,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         5 * time.Millisecond,
+		BreakerThreshold: 3,
+		CooldownPeriod:   20 * time.Millisecond,
+	}
+}
+
+func TestDoStatsSucceedsWithoutRetry(t *testing.T) {
+	b := NewBreaker(fastPolicy())
+	calls := 0
+	stats, err := b.DoStats(context.Background(), "storage", func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DoStats returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("fn called %d times, want 1", calls)
+	}
+	if stats.RetryCount != 0 {
+		t.Fatalf("RetryCount = %d, want 0", stats.RetryCount)
+	}
+}
+
+func TestDoStatsRetriesThenSucceeds(t *testing.T) {
+	b := NewBreaker(fastPolicy())
+	calls := 0
+	stats, err := b.DoStats(context.Background(), "storage", func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DoStats returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("fn called %d times, want 3", calls)
+	}
+	if stats.RetryCount != 2 {
+		t.Fatalf("RetryCount = %d, want 2", stats.RetryCount)
+	}
+	if stats.BackoffWaitSec <= 0 {
+		t.Fatalf("BackoffWaitSec = %v, want > 0", stats.BackoffWaitSec)
+	}
+}
+
+func TestDoStatsExhaustsRetriesAndReturnsLastErr(t *testing.T) {
+	b := NewBreaker(fastPolicy())
+	want := errors.New("still broken")
+	calls := 0
+	_, err := b.DoStats(context.Background(), "storage", func() error {
+		calls++
+		return want
+	})
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("error %v does not wrap %v", err, want)
+	}
+	if calls != fastPolicy().MaxRetries+1 {
+		t.Fatalf("fn called %d times, want %d", calls, fastPolicy().MaxRetries+1)
+	}
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	policy := fastPolicy()
+	policy.MaxRetries = 0 // isolate the breaker threshold from per-call retries
+	b := NewBreaker(policy)
+	failing := func() error { return errors.New("down") }
+
+	for i := 0; i < policy.BreakerThreshold; i++ {
+		if _, err := b.DoStats(context.Background(), "ledger", failing); err == nil {
+			t.Fatalf("attempt %d: expected failure, got nil", i)
+		}
+	}
+
+	calls := 0
+	_, err := b.DoStats(context.Background(), "ledger", func() error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("err = %v, want ErrBreakerOpen", err)
+	}
+	if calls != 0 {
+		t.Fatalf("fn should not run while breaker is open, called %d times", calls)
+	}
+}
+
+func TestBreakerHalfOpenProbeRecoversToClosedOnSuccess(t *testing.T) {
+	policy := fastPolicy()
+	policy.MaxRetries = 0
+	b := NewBreaker(policy)
+	failing := func() error { return errors.New("down") }
+
+	for i := 0; i < policy.BreakerThreshold; i++ {
+		if _, err := b.DoStats(context.Background(), "db", failing); err == nil {
+			t.Fatalf("attempt %d: expected failure, got nil", i)
+		}
+	}
+
+	time.Sleep(policy.CooldownPeriod + 5*time.Millisecond)
+
+	calls := 0
+	_, err := b.DoStats(context.Background(), "db", func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("half-open probe: unexpected error %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("half-open probe should have called fn once, called %d times", calls)
+	}
+
+	e := b.endpoint("db")
+	e.mu.Lock()
+	state := e.state
+	e.mu.Unlock()
+	if state != Closed {
+		t.Fatalf("state after successful probe = %v, want Closed", state)
+	}
+
+	// The breaker should now take new work normally rather than staying latched open.
+	calls = 0
+	if _, err := b.DoStats(context.Background(), "db", func() error {
+		calls++
+		return nil
+	}); err != nil {
+		t.Fatalf("post-recovery call: unexpected error %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("post-recovery call: fn called %d times, want 1", calls)
+	}
+}
+
+func TestDoStatsAbortsOnContextCancelDuringBackoff(t *testing.T) {
+	policy := fastPolicy()
+	policy.BaseDelay = time.Hour // force the backoff wait to outlast the context
+	policy.MaxDelay = time.Hour  // fastPolicy's 5ms cap would otherwise shorten it
+	b := NewBreaker(policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	_, err := b.DoStats(ctx, "storage", func() error {
+		calls++
+		return errors.New("fail")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Fatalf("fn called %d times, want 1 (no retry once context is done)", calls)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"bytes"
 
+	"audit-log/internal/core/resilience"
 )
 
 type Document struct {
@@ -21,21 +23,44 @@ type Document struct {
 	MerkleLeafIndex int
 	MerkleBatchSize int
 
+	// Drand-anchored timestamp (only set when the batcher has a Beacon enabled).
+	DrandRound      uint64
+	DrandRandomness string
+
+	// Chunked storage (only set when chunked storage is enabled). HashHex becomes the Merkle
+	// root over these chunk hashes instead of a whole-file sha256 when chunking is on.
+	ChunkCount int
+	ChunkSize  int
+	ChunkRoot  string
+
+	// Deduplicated is true when the storage backend already held an object matching this
+	// document's content hash, so the upload was skipped (only set when dedup is enabled).
+	Deduplicated bool
+
 	CreatedAt time.Time
 }
 
 type ObjectStorage interface {
-	Upload(filename string, data io.Reader, size int64) (path string, err error)
+	// Upload stores data under a key derived from sha256Hex - the content's hex-encoded
+	// SHA-256, already computed by the caller - and reports whether an object matching that
+	// digest already existed, so identical payloads (e.g. the same intel report re-submitted by
+	// multiple units) aren't stored twice.
+	Upload(sha256Hex string, data io.Reader, size int64) (path string, deduplicated bool, err error)
 }
 
 type Ledger interface {
 	Write(hash string, metadata string) (txID string, err error)
 	Read(hash string) (metadata string, err error)
+	// GetRoot returns the (merkle root or document hash) committed under txID, so a verifier
+	// can compare a locally-reconstructed root against what the ledger actually has on record.
+	GetRoot(txID string) (root []byte, err error)
 }
 
 type Database interface {
 	Save(doc *Document) error
 	Get(id string) (*Document, error)
+	List() ([]*Document, error)
+	SaveCustodyReport(report *CustodyReport) error
 }
 
 type AuditService struct {
@@ -43,8 +68,11 @@ type AuditService struct {
 	db     Database
 	ledger Ledger
 
-	useBC  bool
-	merkle *MerkleBatcher
+	useBC   bool
+	merkle  *MerkleBatcher
+	chunked *ChunkedStore
+	limiter *Limiter
+	breaker *resilience.Breaker
 }
 
 func NewAuditService(store ObjectStorage, db Database, ledger Ledger, useBC bool) *AuditService {
@@ -55,6 +83,44 @@ func (s *AuditService) EnableMerkleBatching(batcher *MerkleBatcher) {
 	s.merkle = batcher
 }
 
+// EnableChunkedStorage switches ProcessDocument to stream uploads through a ChunkedStore
+// instead of writing the whole file as one object. cs should wrap the same backend passed to
+// NewAuditService as store.
+func (s *AuditService) EnableChunkedStorage(cs *ChunkedStore) {
+	s.chunked = cs
+}
+
+// EnableLimiter caps ProcessDocument's storage/ledger throughput and in-flight Merkle batch
+// count through l. Any dimension l leaves disabled (<=0 at construction) never blocks.
+func (s *AuditService) EnableLimiter(l *Limiter) {
+	s.limiter = l
+}
+
+// EnableResilience wraps ProcessDocument's storage put, DB save, and direct ledger submit in
+// retry-with-backoff + circuit breaker via b, so a transient failure in any of those stages
+// degrades latency instead of failing the job outright.
+func (s *AuditService) EnableResilience(b *resilience.Breaker) {
+	s.breaker = b
+}
+
+// withResilience runs fn directly when no breaker is enabled, or through s.breaker.DoStats
+// against op otherwise, returning how much it retried/waited.
+func (s *AuditService) withResilience(op string, fn func() error) (resilience.Stats, error) {
+	if s.breaker == nil {
+		return resilience.Stats{}, fn()
+	}
+	return s.breaker.DoStats(context.Background(), op, fn)
+}
+
+// ReadChunk proxies to the enabled ChunkedStore, if any, so callers don't need to reach past
+// AuditService to pull a single chunk for spot-checking.
+func (s *AuditService) ReadChunk(docID string, index int) ([]byte, []MerkleProofStep, error) {
+	if s.chunked == nil {
+		return nil, nil, fmt.Errorf("chunked storage is not enabled")
+	}
+	return s.chunked.ReadChunk(docID, index)
+}
+
 // ProcessDocument stores the raw content in object storage, stores metadata in DB, and (optionally)
 // commits either the document hash (direct) or a merkle root (batched) to the ledger.
 //
@@ -80,23 +146,69 @@ func (s *AuditService) ProcessDocument(content []byte) (*Document, *DocumentMetr
 	m.HashSec = h1.Sub(h0).Seconds()
 
 	// --- Object storage ---
+	if waited, err := s.limiter.WaitStorage(context.Background(), len(content)); err == nil {
+		m.StorageThrottleSec = waited.Seconds()
+	}
 	s0 := time.Now()
 	m.StorageStartUnixNS = s0.UnixNano()
-	path := fmt.Sprintf("%s.bin", doc.ID)
-	path, err := s.store.Upload(path,  bytes.NewReader(content), int64(len(content)))
-	if err != nil {
-		m.ReqEndUnixNS = time.Now().UnixNano()
-		m.TotalSec = time.Since(reqStart).Seconds()
-		return nil, m, err
+	if s.chunked != nil {
+		var res ChunkUploadResult
+		stats, err := s.withResilience("storage", func() error {
+			var innerErr error
+			res, innerErr = s.chunked.UploadChunked(doc.ID, bytes.NewReader(content))
+			return innerErr
+		})
+		m.StorageRetryCount = stats.RetryCount
+		m.StorageBackoffWaitSec = stats.BackoffWaitSec
+		if err != nil {
+			m.ReqEndUnixNS = time.Now().UnixNano()
+			m.TotalSec = time.Since(reqStart).Seconds()
+			return nil, m, err
+		}
+		m.StorageEndUnixNS = time.Now().UnixNano()
+		m.StorageSec = time.Duration(m.StorageEndUnixNS - m.StorageStartUnixNS).Seconds()
+
+		doc.StoragePath = fmt.Sprintf("%s/chunk-{0..%05d}", doc.ID, res.ChunkCount-1)
+		doc.ChunkCount = res.ChunkCount
+		doc.ChunkSize = res.ChunkSize
+		doc.ChunkRoot = res.ChunkRoot
+		// The Merkle root of the chunk hashes replaces the whole-file hash as the document's
+		// identity: it's what ReadChunk proves against and what the ledger ultimately anchors.
+		doc.HashHex = res.ChunkRoot
+		if decoded, decErr := hex.DecodeString(res.ChunkRoot); decErr == nil {
+			rawHash = decoded
+		}
+
+		m.ChunkHashMinSec, m.ChunkHashMaxSec, m.ChunkHashMeanSec = res.HashTimings.MinSec, res.HashTimings.MaxSec, res.HashTimings.MeanSec
+		m.ChunkStorageMinSec, m.ChunkStorageMaxSec, m.ChunkStorageMeanSec = res.StorageTimings.MinSec, res.StorageTimings.MaxSec, res.StorageTimings.MeanSec
+	} else {
+		var path string
+		var deduped bool
+		stats, err := s.withResilience("storage", func() error {
+			var innerErr error
+			path, deduped, innerErr = s.store.Upload(doc.HashHex, bytes.NewReader(content), int64(len(content)))
+			return innerErr
+		})
+		m.StorageRetryCount = stats.RetryCount
+		m.StorageBackoffWaitSec = stats.BackoffWaitSec
+		if err != nil {
+			m.ReqEndUnixNS = time.Now().UnixNano()
+			m.TotalSec = time.Since(reqStart).Seconds()
+			return nil, m, err
+		}
+		m.StorageEndUnixNS = time.Now().UnixNano()
+		m.StorageSec = time.Duration(m.StorageEndUnixNS - m.StorageStartUnixNS).Seconds()
+		doc.StoragePath = path
+		doc.Deduplicated = deduped
 	}
-	m.StorageEndUnixNS = time.Now().UnixNano()
-	m.StorageSec = time.Duration(m.StorageEndUnixNS - m.StorageStartUnixNS).Seconds()
-	doc.StoragePath = path
 
 	// --- DB ---
 	d0 := time.Now()
 	m.DBStartUnixNS = d0.UnixNano()
-	if err := s.db.Save(doc); err != nil {
+	dbStats, err := s.withResilience("db", func() error { return s.db.Save(doc) })
+	m.DBRetryCount = dbStats.RetryCount
+	m.DBBackoffWaitSec = dbStats.BackoffWaitSec
+	if err != nil {
 		m.DBEndUnixNS = time.Now().UnixNano()
 		m.DBSec = time.Duration(m.DBEndUnixNS - m.DBStartUnixNS).Seconds()
 		m.ReqEndUnixNS = time.Now().UnixNano()
@@ -110,7 +222,15 @@ func (s *AuditService) ProcessDocument(content []byte) (*Document, *DocumentMetr
 		if s.merkle != nil {
 			// --- Merkle batch enqueue + wait ---
 			m.MerkleEnqueueUnixNS = time.Now().UnixNano()
+			release, waited, err := s.limiter.AcquireBatchSlot(context.Background())
+			m.LedgerThrottleSec = waited.Seconds()
+			if err != nil {
+				m.ReqEndUnixNS = time.Now().UnixNano()
+				m.TotalSec = time.Since(reqStart).Seconds()
+				return nil, m, err
+			}
 			res, err := s.merkle.Add(rawHash)
+			release()
 			if err != nil {
 				m.ReqEndUnixNS = time.Now().UnixNano()
 				m.TotalSec = time.Since(reqStart).Seconds()
@@ -120,6 +240,8 @@ func (s *AuditService) ProcessDocument(content []byte) (*Document, *DocumentMetr
 			doc.MerkleLeafIndex = res.Index
 			doc.MerkleBatchSize = res.BatchSize
 			doc.TxID = res.TxID
+			doc.DrandRound = res.DrandRound
+			doc.DrandRandomness = res.DrandRandomness
 
 			// propagate timings from batcher
 			m.MerkleFlushStartUnixNS = res.FlushStartUnixNS
@@ -131,6 +253,8 @@ func (s *AuditService) ProcessDocument(content []byte) (*Document, *DocumentMetr
 
 			m.MerkleLeafIndex = res.Index
 			m.MerkleBatchSize = res.BatchSize
+			m.DrandRound = res.DrandRound
+			m.DrandRandomness = res.DrandRandomness
 
 			if m.MerkleResponseUnixNS > 0 && res.EnqueueUnixNS > 0 {
 				m.MerkleWaitSec = time.Duration(m.MerkleResponseUnixNS - res.EnqueueUnixNS).Seconds()
@@ -141,11 +265,25 @@ func (s *AuditService) ProcessDocument(content []byte) (*Document, *DocumentMetr
 			if m.MerkleLedgerEndUnixNS > 0 && m.MerkleLedgerStartUnixNS > 0 {
 				m.MerkleLedgerSec = time.Duration(m.MerkleLedgerEndUnixNS - m.MerkleLedgerStartUnixNS).Seconds()
 			}
+
+			m.LedgerRetryCount = res.LedgerRetryCount
+			m.LedgerBackoffWaitSec = res.LedgerBackoffWaitSec
+			m.MerkleBuildParallel = res.BuildParallel
 		} else {
 			// --- Direct ledger write ---
+			if waited, err := s.limiter.WaitLedger(context.Background()); err == nil {
+				m.LedgerThrottleSec = waited.Seconds()
+			}
 			l0 := time.Now()
 			m.LedgerStartUnixNS = l0.UnixNano()
-			txID, err := s.ledger.Write(doc.HashHex, "")
+			var txID string
+			ledgerStats, err := s.withResilience("ledger", func() error {
+				var innerErr error
+				txID, innerErr = s.ledger.Write(doc.HashHex, "")
+				return innerErr
+			})
+			m.LedgerRetryCount = ledgerStats.RetryCount
+			m.LedgerBackoffWaitSec = ledgerStats.BackoffWaitSec
 			m.LedgerEndUnixNS = time.Now().UnixNano()
 			m.LedgerSec = time.Duration(m.LedgerEndUnixNS - m.LedgerStartUnixNS).Seconds()
 			if err != nil {
@@ -0,0 +1,218 @@
+package core
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	errInvalidProofJSON = errors.New("invalid proof json")
+	errShortCircuited   = errors.New("batch short-circuited after an earlier failure")
+)
+
+// VerifyRequest is one unit of work submitted to a ProofVerifier.
+type VerifyRequest struct {
+	LeafHex   string
+	ProofJSON string
+	RootHex   string
+	Ctx       context.Context
+	Resp      chan VerifyResult
+}
+
+// VerifyResult is the outcome of verifying a single VerifyRequest.
+type VerifyResult struct {
+	OK  bool
+	Err error
+}
+
+// ProofVerifier owns a bounded worker pool that verifies Merkle inclusion proofs off the
+// caller's goroutine, so a verifier service re-checking thousands of documents doesn't
+// serialize on SHA-256 work and JSON parsing. Submit enqueues onto a buffered job channel and
+// returns a per-request result channel; once the channel fills, Submit blocks, which is the
+// pool's backpressure signal.
+type ProofVerifier struct {
+	jobs chan *verifyJob
+
+	stepsPool sync.Pool // reused []MerkleProofStep decode buffers, avoids a per-call allocation
+
+	verified uint64
+	rejected uint64
+	queued   int64
+
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+type verifyJob struct {
+	req  VerifyRequest
+	resp chan VerifyResult
+}
+
+// NewProofVerifier starts a pool of `workers` goroutines (GOMAXPROCS if workers <= 0) pulling
+// from a job queue of the given buffer size (defaults to 4x workers if queueSize <= 0).
+func NewProofVerifier(workers int, queueSize int) *ProofVerifier {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if queueSize <= 0 {
+		queueSize = workers * 4
+	}
+
+	v := &ProofVerifier{
+		jobs: make(chan *verifyJob, queueSize),
+	}
+	v.stepsPool.New = func() any {
+		steps := make([]MerkleProofStep, 0, 16)
+		return &steps
+	}
+
+	for i := 0; i < workers; i++ {
+		v.wg.Add(1)
+		go v.worker()
+	}
+	return v
+}
+
+func (v *ProofVerifier) worker() {
+	defer v.wg.Done()
+	for job := range v.jobs {
+		atomic.AddInt64(&v.queued, -1)
+
+		var ok bool
+		var err error
+		if ctx := job.req.Ctx; ctx != nil && ctx.Err() != nil {
+			// The caller's deadline/cancellation already fired while this job sat in the
+			// queue - skip the work rather than spend a worker on a result nobody wants.
+			err = ctx.Err()
+		} else {
+			ok, err = v.verify(job.req)
+		}
+
+		if err == nil && ok {
+			atomic.AddUint64(&v.verified, 1)
+		} else {
+			atomic.AddUint64(&v.rejected, 1)
+		}
+		job.resp <- VerifyResult{OK: ok, Err: err}
+	}
+}
+
+func (v *ProofVerifier) verify(req VerifyRequest) (bool, error) {
+	leaf, err := hex.DecodeString(req.LeafHex)
+	if err != nil {
+		return false, errors.New("invalid leaf hash encoding")
+	}
+	expectedRoot, err := hex.DecodeString(req.RootHex)
+	if err != nil {
+		return false, errors.New("invalid root hash encoding")
+	}
+
+	stepsPtr := v.stepsPool.Get().(*[]MerkleProofStep)
+	*stepsPtr = (*stepsPtr)[:0]
+	defer v.stepsPool.Put(stepsPtr)
+
+	if err := json.Unmarshal([]byte(req.ProofJSON), stepsPtr); err != nil {
+		return false, errInvalidProofJSON
+	}
+
+	computed, err := computeRootFromProof(leaf, *stepsPtr)
+	if err != nil {
+		return false, err
+	}
+
+	if len(computed) != len(expectedRoot) {
+		return false, nil
+	}
+	for i := range computed {
+		if computed[i] != expectedRoot[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Submit enqueues a verify request and returns a channel that receives exactly one
+// VerifyResult. It blocks once the internal job queue is full; that's the pool's
+// backpressure mechanism, so callers that want a non-blocking call site should invoke
+// Submit from their own goroutine. If req.Ctx is set and is cancelled/expires while still
+// waiting for queue space, Submit gives up on enqueueing and reports that on the result channel
+// instead of blocking indefinitely.
+func (v *ProofVerifier) Submit(req VerifyRequest) <-chan VerifyResult {
+	resp := req.Resp
+	if resp == nil {
+		resp = make(chan VerifyResult, 1)
+	}
+	job := &verifyJob{req: req, resp: resp}
+
+	if req.Ctx == nil {
+		atomic.AddInt64(&v.queued, 1)
+		v.jobs <- job
+		return resp
+	}
+
+	select {
+	case v.jobs <- job:
+		atomic.AddInt64(&v.queued, 1)
+	case <-req.Ctx.Done():
+		resp <- VerifyResult{OK: false, Err: req.Ctx.Err()}
+	}
+	return resp
+}
+
+// SubmitBatch submits every request in reqs and waits for all results, preserving order. If
+// failFast is set, once one result comes back false or errored, the remaining results are
+// reported as short-circuited rather than waited on individually - already-enqueued jobs
+// still run to completion (workers don't cancel mid-verify), but the caller stops waiting on
+// the slow tail once the batch is known to be bad.
+func (v *ProofVerifier) SubmitBatch(reqs []VerifyRequest, failFast bool) []VerifyResult {
+	chans := make([]<-chan VerifyResult, len(reqs))
+	for i, req := range reqs {
+		chans[i] = v.Submit(req)
+	}
+
+	results := make([]VerifyResult, len(reqs))
+	failed := false
+	for i, ch := range chans {
+		if failFast && failed {
+			results[i] = VerifyResult{OK: false, Err: errShortCircuited}
+			continue
+		}
+		results[i] = <-ch
+		if !results[i].OK || results[i].Err != nil {
+			failed = true
+		}
+	}
+	return results
+}
+
+// VerifierStats is a point-in-time snapshot of the pool's counters, Prometheus-gauge-shaped.
+type VerifierStats struct {
+	Verified uint64
+	Rejected uint64
+	Queued   int64
+}
+
+func (v *ProofVerifier) Stats() VerifierStats {
+	return VerifierStats{
+		Verified: atomic.LoadUint64(&v.verified),
+		Rejected: atomic.LoadUint64(&v.rejected),
+		Queued:   atomic.LoadInt64(&v.queued),
+	}
+}
+
+// Close stops accepting new work and waits for in-flight jobs to drain.
+func (v *ProofVerifier) Close() {
+	v.closeOnce.Do(func() {
+		close(v.jobs)
+		v.wg.Wait()
+	})
+}
+
+// defaultVerifier backs the package-level VerifyMerkleProof helper so existing callers keep
+// working unchanged while getting pooled, off-goroutine verification underneath.
+var defaultVerifier = NewProofVerifier(0, 0)
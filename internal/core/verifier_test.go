@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// buildTestProof builds a 4-leaf Merkle tree and returns the hex-encoded leaf, its inclusion
+// proof JSON, and the hex-encoded root, so verifier tests don't need to special-case the
+// single-leaf tree shape.
+func buildTestProof(t *testing.T, leafIndex int) (leafHex, proofJSON, rootHex string) {
+	t.Helper()
+	leaves := make([][]byte, 4)
+	for i := range leaves {
+		sum := sha256.Sum256([]byte{byte(i)})
+		leaves[i] = sum[:]
+	}
+	levels, _, err := buildMerkleLevels(leaves)
+	if err != nil {
+		t.Fatalf("buildMerkleLevels: %v", err)
+	}
+	proof, err := merkleProof(levels, leafIndex)
+	if err != nil {
+		t.Fatalf("merkleProof: %v", err)
+	}
+	raw, err := json.Marshal(proof)
+	if err != nil {
+		t.Fatalf("marshal proof: %v", err)
+	}
+	return hex.EncodeToString(leaves[leafIndex]), string(raw), hex.EncodeToString(merkleRootFromLevels(levels))
+}
+
+func TestProofVerifierValidProof(t *testing.T) {
+	v := NewProofVerifier(2, 4)
+	defer v.Close()
+
+	leaf, proof, root := buildTestProof(t, 1)
+	res := <-v.Submit(VerifyRequest{LeafHex: leaf, ProofJSON: proof, RootHex: root})
+	if res.Err != nil || !res.OK {
+		t.Fatalf("valid proof: OK=%v err=%v, want OK=true err=nil", res.OK, res.Err)
+	}
+
+	stats := v.Stats()
+	if stats.Verified != 1 || stats.Rejected != 0 {
+		t.Fatalf("Stats = %+v, want Verified=1 Rejected=0", stats)
+	}
+}
+
+func TestProofVerifierWrongRoot(t *testing.T) {
+	v := NewProofVerifier(2, 4)
+	defer v.Close()
+
+	leaf, proof, _ := buildTestProof(t, 1)
+	otherLeafSum := sha256.Sum256([]byte("not the root"))
+	res := <-v.Submit(VerifyRequest{LeafHex: leaf, ProofJSON: proof, RootHex: hex.EncodeToString(otherLeafSum[:])})
+	if res.Err != nil {
+		t.Fatalf("mismatched root: unexpected error %v", res.Err)
+	}
+	if res.OK {
+		t.Fatal("mismatched root: OK=true, want false")
+	}
+}
+
+func TestProofVerifierInvalidEncodings(t *testing.T) {
+	v := NewProofVerifier(2, 4)
+	defer v.Close()
+
+	_, proof, root := buildTestProof(t, 0)
+
+	tests := []struct {
+		name string
+		req  VerifyRequest
+	}{
+		{"bad leaf hex", VerifyRequest{LeafHex: "not-hex", ProofJSON: proof, RootHex: root}},
+		{"bad root hex", VerifyRequest{LeafHex: hex.EncodeToString([]byte("x")), ProofJSON: proof, RootHex: "not-hex"}},
+		{"bad proof json", VerifyRequest{LeafHex: hex.EncodeToString([]byte("x")), ProofJSON: "not-json", RootHex: root}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := <-v.Submit(tc.req)
+			if res.Err == nil {
+				t.Fatal("expected an error")
+			}
+			if res.OK {
+				t.Fatal("OK=true on an invalid request")
+			}
+		})
+	}
+}
+
+func TestProofVerifierSubmitSkipsAlreadyCancelledRequest(t *testing.T) {
+	v := NewProofVerifier(1, 1)
+	defer v.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	leaf, proof, root := buildTestProof(t, 0)
+	res := <-v.Submit(VerifyRequest{LeafHex: leaf, ProofJSON: proof, RootHex: root, Ctx: ctx})
+	if res.Err != context.Canceled {
+		t.Fatalf("err = %v, want context.Canceled", res.Err)
+	}
+	if res.OK {
+		t.Fatal("OK=true for a request whose context was already cancelled")
+	}
+}
+
+func TestProofVerifierSubmitUnblocksOnContextWhileQueueFull(t *testing.T) {
+	// No workers draining v.jobs: the first Submit fills the single buffered slot, so a second
+	// Submit has nowhere to go and must block on its select - it should give up as soon as its
+	// own context expires instead of blocking forever.
+	v := &ProofVerifier{jobs: make(chan *verifyJob, 1)}
+
+	leaf, proof, root := buildTestProof(t, 0)
+	v.Submit(VerifyRequest{LeafHex: leaf, ProofJSON: proof, RootHex: root}) // fills the queue
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	res := <-v.Submit(VerifyRequest{LeafHex: leaf, ProofJSON: proof, RootHex: root, Ctx: ctx})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Submit took %v to honor ctx cancellation, want well under 1s", elapsed)
+	}
+	if res.Err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", res.Err)
+	}
+}
+
+func TestProofVerifierSubmitBatchFailFastShortCircuits(t *testing.T) {
+	v := NewProofVerifier(2, 8)
+	defer v.Close()
+
+	goodLeaf, goodProof, root := buildTestProof(t, 0)
+	reqs := []VerifyRequest{
+		{LeafHex: "not-hex", ProofJSON: goodProof, RootHex: root}, // fails
+		{LeafHex: goodLeaf, ProofJSON: goodProof, RootHex: root},
+		{LeafHex: goodLeaf, ProofJSON: goodProof, RootHex: root},
+	}
+	results := v.SubmitBatch(reqs, true)
+	if results[0].Err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Err != errShortCircuited {
+			t.Fatalf("results[%d].Err = %v, want errShortCircuited", i, results[i].Err)
+		}
+	}
+}
+
+func TestProofVerifierSubmitBatchWithoutFailFastRunsAll(t *testing.T) {
+	v := NewProofVerifier(2, 8)
+	defer v.Close()
+
+	goodLeaf, goodProof, root := buildTestProof(t, 0)
+	reqs := []VerifyRequest{
+		{LeafHex: "not-hex", ProofJSON: goodProof, RootHex: root},
+		{LeafHex: goodLeaf, ProofJSON: goodProof, RootHex: root},
+	}
+	results := v.SubmitBatch(reqs, false)
+	if results[0].Err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	if results[1].Err != nil || !results[1].OK {
+		t.Fatalf("second request = %+v, want OK=true err=nil", results[1])
+	}
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"audit-log/internal/core"
+	"github.com/dgraph-io/badger/v4"
+)
+
+func docKey(id string) []byte     { return []byte("doc:" + id) }
+func custodyKey(id string) []byte { return []byte("custody:" + id) }
+func docKeyPrefix() []byte        { return []byte("doc:") }
+
+// BadgerDB is a core.Database backed by an embedded BadgerDB LSM-tree store.
+type BadgerDB struct {
+	db *badger.DB
+}
+
+// NewBadgerDB opens (creating if necessary) a badger store rooted at path.
+func NewBadgerDB(path string) (*BadgerDB, error) {
+	opts := badger.DefaultOptions(path)
+	opts.Logger = nil
+	bdb, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("badger: open %s: %w", path, err)
+	}
+	return &BadgerDB{db: bdb}, nil
+}
+
+func (b *BadgerDB) Save(doc *core.Document) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(docKey(doc.ID), raw)
+	})
+}
+
+func (b *BadgerDB) Get(id string) (*core.Document, error) {
+	var doc core.Document
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(docKey(id))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			return json.Unmarshal(val, &doc)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("badger: get %s: %w", id, err)
+	}
+	return &doc, nil
+}
+
+func (b *BadgerDB) List() ([]*core.Document, error) {
+	var docs []*core.Document
+	err := b.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		prefix := docKeyPrefix()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			err := item.Value(func(val []byte) error {
+				var doc core.Document
+				if err := json.Unmarshal(val, &doc); err != nil {
+					return err
+				}
+				docs = append(docs, &doc)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (b *BadgerDB) SaveCustodyReport(report *core.CustodyReport) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(custodyKey(report.ID), raw)
+	})
+}
+
+// Close releases the underlying Badger handles (including its background compaction goroutines).
+func (b *BadgerDB) Close() error {
+	return b.db.Close()
+}
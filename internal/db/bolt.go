@@ -0,0 +1,99 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"audit-log/internal/core"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	docsBucket           = []byte("documents")
+	custodyReportsBucket = []byte("custody_reports")
+)
+
+// BoltDB is a core.Database backed by an embedded bbolt file - for operators running this
+// module on edge nodes without a Postgres server nearby.
+type BoltDB struct {
+	db *bolt.DB
+}
+
+// NewBoltDB opens (creating if necessary) a bbolt file at path and ensures its buckets exist.
+func NewBoltDB(path string) (*BoltDB, error) {
+	bdb, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bolt: open %s: %w", path, err)
+	}
+
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(docsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(custodyReportsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt: init buckets: %w", err)
+	}
+
+	return &BoltDB{db: bdb}, nil
+}
+
+func (b *BoltDB) Save(doc *core.Document) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(docsBucket).Put([]byte(doc.ID), raw)
+	})
+}
+
+func (b *BoltDB) Get(id string) (*core.Document, error) {
+	var doc core.Document
+	err := b.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(docsBucket).Get([]byte(id))
+		if raw == nil {
+			return fmt.Errorf("document not found: %s", id)
+		}
+		return json.Unmarshal(raw, &doc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (b *BoltDB) List() ([]*core.Document, error) {
+	var docs []*core.Document
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(docsBucket).ForEach(func(_, v []byte) error {
+			var doc core.Document
+			if err := json.Unmarshal(v, &doc); err != nil {
+				return err
+			}
+			docs = append(docs, &doc)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (b *BoltDB) SaveCustodyReport(report *core.CustodyReport) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(custodyReportsBucket).Put([]byte(report.ID), raw)
+	})
+}
+
+// Close releases the underlying bbolt file handle.
+func (b *BoltDB) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"audit-log/internal/core"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// GoLevelDB is a core.Database backed by an embedded goleveldb store.
+type GoLevelDB struct {
+	db *leveldb.DB
+}
+
+// NewGoLevelDB opens (creating if necessary) a goleveldb store rooted at path.
+func NewGoLevelDB(path string) (*GoLevelDB, error) {
+	ldb, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("goleveldb: open %s: %w", path, err)
+	}
+	return &GoLevelDB{db: ldb}, nil
+}
+
+func (g *GoLevelDB) Save(doc *core.Document) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return g.db.Put(docKey(doc.ID), raw, nil)
+}
+
+func (g *GoLevelDB) Get(id string) (*core.Document, error) {
+	raw, err := g.db.Get(docKey(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("goleveldb: get %s: %w", id, err)
+	}
+	var doc core.Document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (g *GoLevelDB) List() ([]*core.Document, error) {
+	var docs []*core.Document
+	iter := g.db.NewIterator(util.BytesPrefix(docKeyPrefix()), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var doc core.Document
+		if err := json.Unmarshal(iter.Value(), &doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (g *GoLevelDB) SaveCustodyReport(report *core.CustodyReport) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return g.db.Put(custodyKey(report.ID), raw, nil)
+}
+
+// Close releases the underlying goleveldb file handles.
+func (g *GoLevelDB) Close() error {
+	return g.db.Close()
+}
@@ -9,27 +9,47 @@ import (
 
 // MemoryDB is a simple in-memory implementation of core.Database (useful for unit tests).
 type MemoryDB struct {
-	mu   sync.RWMutex
-	docs map[string]core.Document
+	mu             sync.RWMutex
+	docs           map[string]*core.Document
+	custodyReports []*core.CustodyReport
 }
 
 func NewMemoryDB() *MemoryDB {
-	return &MemoryDB{docs: make(map[string]core.Document)}
+	return &MemoryDB{docs: make(map[string]*core.Document)}
 }
 
-func (m *MemoryDB) Save(doc core.Document) error {
+func (m *MemoryDB) Save(doc *core.Document) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.docs[doc.ID] = doc
 	return nil
 }
 
-func (m *MemoryDB) Get(docID string) (core.Document, error) {
+func (m *MemoryDB) Get(id string) (*core.Document, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	doc, ok := m.docs[docID]
+	doc, ok := m.docs[id]
 	if !ok {
-		return core.Document{}, errors.New("document not found")
+		return nil, errors.New("document not found")
 	}
 	return doc, nil
 }
+
+// List returns every stored document.
+func (m *MemoryDB) List() ([]*core.Document, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	docs := make([]*core.Document, 0, len(m.docs))
+	for _, doc := range m.docs {
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// SaveCustodyReport appends a custody sweep report.
+func (m *MemoryDB) SaveCustodyReport(report *core.CustodyReport) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.custodyReports = append(m.custodyReports, report)
+	return nil
+}
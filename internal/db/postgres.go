@@ -21,8 +21,8 @@ func NewPostgresDB(host, user, password, dbName, port string) (*PostgresDB, erro
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Автоматична міграція: GORM сам створить таблицю 'documents' на основі структури
-	err = database.AutoMigrate(&core.Document{})
+	// Автоматична міграція: GORM сам створить таблиці 'documents' та 'custody_reports'
+	err = database.AutoMigrate(&core.Document{}, &core.CustodyReport{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
@@ -42,13 +42,32 @@ func (p *PostgresDB) Save(doc *core.Document) error {
 // Get шукає документ за ID (PrimaryKey)
 func (p *PostgresDB) Get(id string) (*core.Document, error) {
 	var doc core.Document
-	
+
 	// GORM SQL: SELECT * FROM documents WHERE id = '...' LIMIT 1;
 	result := p.db.First(&doc, "id = ?", id)
-	
+
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	
+
 	return &doc, nil
+}
+
+// List повертає всі документи (використовується CustodyProver для обходу всього сховища)
+func (p *PostgresDB) List() ([]*core.Document, error) {
+	var docs []*core.Document
+	result := p.db.Find(&docs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return docs, nil
+}
+
+// SaveCustodyReport зберігає результат одного проходу custody-перевірки
+func (p *PostgresDB) SaveCustodyReport(report *core.CustodyReport) error {
+	result := p.db.Create(report)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"strings"
+
+	"audit-log/internal/core"
+)
+
+// PrefixDB namespaces another core.Database's document IDs by a fixed prefix (e.g. a tenant
+// or classification level), so a single embedded store can host multiple isolated audit
+// streams instead of needing one store per tenant.
+type PrefixDB struct {
+	inner  core.Database
+	prefix string
+}
+
+// NewPrefixDB wraps inner so every document ID is namespaced under prefix.
+func NewPrefixDB(inner core.Database, prefix string) *PrefixDB {
+	return &PrefixDB{inner: inner, prefix: prefix}
+}
+
+func (p *PrefixDB) namespace(id string) string {
+	return p.prefix + ":" + id
+}
+
+// Save passes a namespaced copy of doc to inner, never mutating the caller's doc: inner
+// implementations that keep the pointer they're given (e.g. MemoryDB) would otherwise end up
+// storing an object whose ID gets silently reverted to the un-namespaced form the moment this
+// call returns.
+func (p *PrefixDB) Save(doc *core.Document) error {
+	namespaced := *doc
+	namespaced.ID = p.namespace(doc.ID)
+	return p.inner.Save(&namespaced)
+}
+
+// Get returns a copy of what inner has stored, with the namespace prefix trimmed off its ID -
+// mutating the pointer inner returned in place would corrupt its backing store for any inner
+// implementation that hands back a live pointer (e.g. MemoryDB), silently dropping the document
+// from a later List() call.
+func (p *PrefixDB) Get(id string) (*core.Document, error) {
+	doc, err := p.inner.Get(p.namespace(id))
+	if err != nil {
+		return nil, err
+	}
+	out := *doc
+	out.ID = strings.TrimPrefix(out.ID, p.prefix+":")
+	return &out, nil
+}
+
+func (p *PrefixDB) List() ([]*core.Document, error) {
+	all, err := p.inner.List()
+	if err != nil {
+		return nil, err
+	}
+	prefix := p.prefix + ":"
+	docs := make([]*core.Document, 0, len(all))
+	for _, doc := range all {
+		if !strings.HasPrefix(doc.ID, prefix) {
+			continue
+		}
+		out := *doc
+		out.ID = strings.TrimPrefix(out.ID, prefix)
+		docs = append(docs, &out)
+	}
+	return docs, nil
+}
+
+// SaveCustodyReport passes through unchanged: a custody report summarizes a whole sweep and
+// isn't scoped to one tenant's document namespace.
+func (p *PrefixDB) SaveCustodyReport(report *core.CustodyReport) error {
+	return p.inner.SaveCustodyReport(report)
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"audit-log/internal/core"
+)
+
+func TestOpenUnknownScheme(t *testing.T) {
+	if _, err := Open("redis://localhost:6379"); err == nil {
+		t.Fatal("expected an error for an unknown scheme")
+	}
+}
+
+func TestOpenMalformedPrefixDSN(t *testing.T) {
+	if _, err := Open("prefix:no-separator"); err == nil {
+		t.Fatal("expected an error for a prefix dsn missing '<namespace>+<dsn>'")
+	}
+}
+
+func TestOpenEachEmbeddedBackend(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  func(dir string) string
+	}{
+		{"memory", func(dir string) string { return "memory://" }},
+		{"bolt", func(dir string) string { return "bolt://" + filepath.Join(dir, "audit.db") }},
+		{"badger", func(dir string) string { return "badger://" + filepath.Join(dir, "badger") }},
+		{"goleveldb", func(dir string) string { return "goleveldb://" + filepath.Join(dir, "leveldb") }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dsn := tc.dsn(t.TempDir())
+			database, err := Open(dsn)
+			if err != nil {
+				t.Fatalf("Open(%q): %v", dsn, err)
+			}
+
+			doc := &core.Document{ID: "doc-1", HashHex: "deadbeef"}
+			if err := database.Save(doc); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			got, err := database.Get("doc-1")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.HashHex != doc.HashHex {
+				t.Fatalf("Get returned HashHex %q, want %q", got.HashHex, doc.HashHex)
+			}
+
+			docs, err := database.List()
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(docs) != 1 {
+				t.Fatalf("List returned %d docs, want 1", len(docs))
+			}
+		})
+	}
+}
+
+func TestOpenPrefixWrapsBackendAndNamespacesKeys(t *testing.T) {
+	database, err := Open("prefix:tenant-a+memory://")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, ok := database.(*PrefixDB); !ok {
+		t.Fatalf("Open(prefix:...) returned %T, want *PrefixDB", database)
+	}
+
+	doc := &core.Document{ID: "doc-1", HashHex: "cafef00d"}
+	if err := database.Save(doc); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := database.Get("doc-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ID != "doc-1" {
+		t.Fatalf("Get returned ID %q, want the un-namespaced %q", got.ID, "doc-1")
+	}
+
+	docs, err := database.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(docs) != 1 || docs[0].ID != "doc-1" {
+		t.Fatalf("List = %+v, want a single doc with ID %q", docs, "doc-1")
+	}
+}
+
+func TestOpenPrefixRecursesOverNestedDSN(t *testing.T) {
+	// prefix: itself takes a dsn after the namespace separator, so it must recurse through Open
+	// rather than only handling bare backend schemes.
+	database, err := Open("prefix:outer+prefix:inner+memory://")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, ok := database.(*PrefixDB); !ok {
+		t.Fatalf("Open(nested prefix:...) returned %T, want *PrefixDB", database)
+	}
+}
@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path"
@@ -141,6 +142,21 @@ func (f *FabricLedger) Write(hash string, metadata string) (string, error) {
 	return transaction.TransactionID(), nil
 }
 
+// GetRoot повертає закомічений хеш/merkle-root за ID транзакції, щоб audit-verify міг
+// звірити локально відновлений корінь із тим, що реально записано в реєстрі.
+func (f *FabricLedger) GetRoot(txID string) ([]byte, error) {
+	result, err := f.contract.EvaluateTransaction("ReadAssetByTxID", txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root for tx %s: %w", txID, err)
+	}
+
+	root, err := hex.DecodeString(string(result))
+	if err != nil {
+		return nil, fmt.Errorf("invalid root encoding from ledger: %w", err)
+	}
+	return root, nil
+}
+
 func (f *FabricLedger) Close() {
 	f.gateway.Close()
 	f.clientConnection.Close()
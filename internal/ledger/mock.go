@@ -3,17 +3,61 @@ package ledger
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"sync"
 	"time"
 )
 
-type MockLedger struct {}
+type MockLedger struct {
+	mu       sync.Mutex
+	roots    map[string]string // txID -> committed hash/root hex
+	metadata map[string]string // hash/root hex -> metadata passed to Write
+}
 
 func NewMockLedger() *MockLedger {
-	return &MockLedger{}
+	return &MockLedger{roots: make(map[string]string), metadata: make(map[string]string)}
 }
 
 func (m *MockLedger) Write(hash string, metadata string) (string, error) {
 	time.Sleep(200 * time.Millisecond) // Simulating network delay
 	dummyTx := sha256.Sum256([]byte(hash + time.Now().String()))
-	return "0x" + hex.EncodeToString(dummyTx[:]), nil
+	txID := "0x" + hex.EncodeToString(dummyTx[:])
+
+	m.mu.Lock()
+	if m.roots == nil {
+		m.roots = make(map[string]string)
+	}
+	if m.metadata == nil {
+		m.metadata = make(map[string]string)
+	}
+	m.roots[txID] = hash
+	m.metadata[hash] = metadata
+	m.mu.Unlock()
+
+	return txID, nil
+}
+
+// Read returns the metadata that was passed to Write for hash, mirroring FabricLedger.Read so
+// MockLedger satisfies core.Ledger for -mode=baseline runs.
+func (m *MockLedger) Read(hash string) (string, error) {
+	m.mu.Lock()
+	metadata, ok := m.metadata[hash]
+	m.mu.Unlock()
+	if !ok {
+		return "", errors.New("unknown hash")
+	}
+	return metadata, nil
+}
+
+// GetRoot returns the hash/root that was passed to Write for txID, so MockLedger satisfies
+// core.Ledger. It only knows roots written through this same instance: the fresh MockLedger
+// that cmd/audit-verify builds for -mode=baseline holds none, so GetRoot fails there.
+func (m *MockLedger) GetRoot(txID string) ([]byte, error) {
+	m.mu.Lock()
+	hashHex, ok := m.roots[txID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, errors.New("unknown tx id")
+	}
+	return hex.DecodeString(hashHex)
 }
@@ -8,14 +8,21 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 type MinioStorage struct {
 	client     *minio.Client
 	bucketName string
+	dedup      bool
+	sse        encrypt.ServerSide
 }
 
-func NewMinioStorage(endpoint, accessKey, secretKey, bucket string) *MinioStorage {
+// NewMinioStorage connects to MinIO and ensures bucket exists. When dedup is true, Upload skips
+// re-uploading content whose sha256 digest is already present in the bucket. sseKey, if
+// non-nil, must be a 32-byte AES-256 key; every object is then encrypted at rest with SSE-C
+// using that key, so the payload is opaque to the MinIO operator.
+func NewMinioStorage(endpoint, accessKey, secretKey, bucket string, dedup bool, sseKey []byte) *MinioStorage {
 	// 1. Ініціалізація клієнта
 	minioClient, err := minio.New(endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
@@ -33,22 +40,78 @@ func NewMinioStorage(endpoint, accessKey, secretKey, bucket string) *MinioStorag
 		fmt.Printf("Created bucket: %s\n", bucket)
 	}
 
+	var sse encrypt.ServerSide
+	if len(sseKey) > 0 {
+		sse, err = encrypt.NewSSEC(sseKey)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
 	return &MinioStorage{
 		client:     minioClient,
 		bucketName: bucket,
+		dedup:      dedup,
+		sse:        sse,
+	}
+}
+
+// contentAddressedKey spreads objects across a two-level prefix (sha256/aa/bb/<hex>) so a
+// single bucket doesn't end up with millions of siblings in one flat listing.
+func contentAddressedKey(sha256Hex string) string {
+	if len(sha256Hex) < 4 {
+		return fmt.Sprintf("sha256/%s", sha256Hex)
 	}
+	return fmt.Sprintf("sha256/%s/%s/%s", sha256Hex[0:2], sha256Hex[2:4], sha256Hex)
 }
 
-func (s *MinioStorage) Upload(filename string, data io.Reader, size int64) (string, error) {
+// Download fetches a previously uploaded object back from MinIO, satisfying
+// core.ObjectDownloader so callers like core.ChunkedStore can pull a single chunk.
+func (s *MinioStorage) Download(path string) (io.ReadCloser, error) {
 	ctx := context.Background()
+	opts := minio.GetObjectOptions{}
+	if s.sse != nil {
+		opts.ServerSideEncryption = s.sse
+	}
+	obj, err := s.client.GetObject(ctx, s.bucketName, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
 
-	// Завантаження потоку даних
-	info, err := s.client.PutObject(ctx, s.bucketName, filename, data, size, minio.PutObjectOptions{
+// Upload stores data under a content-addressable key (sha256/aa/bb/<hex>) derived from
+// sha256Hex. When dedup is enabled, it first calls StatObject for that key and, if an object
+// is already there, skips the PutObject entirely and reports deduplicated=true - the same
+// intel report re-submitted by multiple units should only ever occupy storage once. The
+// returned path is the bare object key (not bucket-prefixed), so it round-trips straight back
+// into Download.
+func (s *MinioStorage) Upload(sha256Hex string, data io.Reader, size int64) (string, bool, error) {
+	ctx := context.Background()
+	key := contentAddressedKey(sha256Hex)
+
+	if s.dedup {
+		statOpts := minio.StatObjectOptions{}
+		if s.sse != nil {
+			statOpts.ServerSideEncryption = s.sse
+		}
+		if _, err := s.client.StatObject(ctx, s.bucketName, key, statOpts); err == nil {
+			return key, true, nil
+		}
+	}
+
+	putOpts := minio.PutObjectOptions{
 		ContentType: "application/pdf", // Можна змінювати динамічно
-	})
+	}
+	if s.sse != nil {
+		putOpts.ServerSideEncryption = s.sse
+	}
+
+	// Завантаження потоку даних
+	info, err := s.client.PutObject(ctx, s.bucketName, key, data, size, putOpts)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
-	return fmt.Sprintf("%s/%s", s.bucketName, info.Key), nil
+	return info.Key, false, nil
 }